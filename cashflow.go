@@ -0,0 +1,209 @@
+package finance
+
+import (
+	"math"
+	"time"
+)
+
+// NPV returns the net present value of a series of cashflows spaced one
+// regular period apart, discounted at rate. The first cashflow occurs
+// now and is not discounted.
+//
+//                n-1  cashflows[i]
+// NPV = sum_{i=0}   ----------------
+//                     (1 + rate)^i
+//
+func NPV(rate float64, cashflows []float64) float64 {
+	npv := 0.0
+	for i, cf := range cashflows {
+		npv += cf / math.Pow(1+rate, float64(i))
+	}
+
+	return npv
+}
+
+// IRR returns the internal rate of return for a series of cashflows,
+// i.e. the rate for which NPV(rate, cashflows) is zero. It solves the
+// equation with Newton's method starting from guess, falling back to
+// the secant method if the derivative vanishes or Newton fails to
+// converge within MaxIterations.
+func IRR(cashflows []float64, guess float64) float64 {
+	if !hasSignChange(cashflows) {
+		return math.NaN()
+	}
+
+	if rate, ok := newton(guess, func(rate float64) float64 {
+		return NPV(rate, cashflows)
+	}, func(rate float64) float64 {
+		return npvDerivative(rate, cashflows)
+	}); ok {
+		return rate
+	}
+
+	return secant(guess, func(rate float64) float64 {
+		return NPV(rate, cashflows)
+	})
+}
+
+// MIRR returns the modified internal rate of return for a series of
+// cashflows, using financeRate as the cost of financing negative
+// cashflows and reinvestRate as the return earned on positive
+// cashflows reinvested until the end of the series.
+func MIRR(cashflows []float64, financeRate, reinvestRate float64) float64 {
+	n := len(cashflows)
+	if n < 2 || !hasSignChange(cashflows) {
+		return math.NaN()
+	}
+
+	var pv, fv float64
+	for i, cf := range cashflows {
+		switch {
+		case cf < 0:
+			pv += cf / math.Pow(1+financeRate, float64(i))
+		case cf > 0:
+			fv += cf * math.Pow(1+reinvestRate, float64(n-1-i))
+		}
+	}
+
+	if pv == 0 || fv == 0 {
+		return math.NaN()
+	}
+
+	return math.Pow(-fv/pv, 1/float64(n-1)) - 1
+}
+
+// XNPV returns the net present value of a series of cashflows that are
+// not necessarily evenly spaced, using dates to compute an Actual/365
+// day-count from the first date.
+func XNPV(rate float64, cashflows []float64, dates []time.Time) float64 {
+	if len(cashflows) != len(dates) || len(dates) == 0 {
+		return math.NaN()
+	}
+
+	d0 := dates[0]
+	npv := 0.0
+	for i, cf := range cashflows {
+		years := dates[i].Sub(d0).Hours() / 24 / 365
+		npv += cf / math.Pow(1+rate, years)
+	}
+
+	return npv
+}
+
+// XIRR returns the internal rate of return for a series of cashflows
+// associated with dates, i.e. the rate for which XNPV(rate, cashflows,
+// dates) is zero. It uses the same Newton-with-secant-fallback
+// strategy as IRR.
+func XIRR(cashflows []float64, dates []time.Time, guess float64) float64 {
+	if len(cashflows) != len(dates) || !hasSignChange(cashflows) {
+		return math.NaN()
+	}
+
+	if rate, ok := newton(guess, func(rate float64) float64 {
+		return XNPV(rate, cashflows, dates)
+	}, func(rate float64) float64 {
+		return xnpvDerivative(rate, cashflows, dates)
+	}); ok {
+		return rate
+	}
+
+	return secant(guess, func(rate float64) float64 {
+		return XNPV(rate, cashflows, dates)
+	})
+}
+
+// hasSignChange reports whether cashflows contains both a positive and
+// a negative value, a necessary condition for IRR/XIRR to have a
+// solution.
+func hasSignChange(cashflows []float64) bool {
+	var hasPositive, hasNegative bool
+	for _, cf := range cashflows {
+		if cf > 0 {
+			hasPositive = true
+		}
+		if cf < 0 {
+			hasNegative = true
+		}
+	}
+
+	return hasPositive && hasNegative
+}
+
+func npvDerivative(rate float64, cashflows []float64) float64 {
+	d := 0.0
+	for i, cf := range cashflows {
+		if i == 0 {
+			continue
+		}
+		d -= float64(i) * cf / math.Pow(1+rate, float64(i+1))
+	}
+
+	return d
+}
+
+func xnpvDerivative(rate float64, cashflows []float64, dates []time.Time) float64 {
+	d0 := dates[0]
+	d := 0.0
+	for i, cf := range cashflows {
+		years := dates[i].Sub(d0).Hours() / 24 / 365
+		if years == 0 {
+			continue
+		}
+		d -= years * cf / math.Pow(1+rate, years+1)
+	}
+
+	return d
+}
+
+// newton solves f(rate) = 0 by Newton-Raphson starting from guess,
+// respecting Accuracy and MaxIterations. The second return value is
+// false if the derivative vanishes or the iteration diverges, in
+// which case the caller should fall back to secant.
+func newton(guess float64, f, df func(rate float64) float64) (float64, bool) {
+	rate := guess
+	for i := 0; i < MaxIterations; i++ {
+		y := f(rate)
+		if math.Abs(y) < Accuracy {
+			return rate, true
+		}
+
+		d := df(rate)
+		if d == 0 || math.IsNaN(d) {
+			return 0, false
+		}
+
+		next := rate - y/d
+		if math.IsNaN(next) || math.IsInf(next, 0) {
+			return 0, false
+		}
+		rate = next
+	}
+
+	return 0, false
+}
+
+// secant solves f(rate) = 0 by the secant method, starting from guess
+// and guess+0.1. It returns NaN if it fails to converge within
+// MaxIterations.
+func secant(guess float64, f func(rate float64) float64) float64 {
+	x0, x1 := guess, guess+0.1
+	y0, y1 := f(x0), f(x1)
+
+	i := 0
+	for math.Abs(y1-y0) > Accuracy && i < MaxIterations {
+		if y1 == y0 {
+			return math.NaN()
+		}
+
+		rate := (y1*x0 - y0*x1) / (y1 - y0)
+		x0, x1 = x1, rate
+		y0, y1 = y1, f(x1)
+		i++
+	}
+
+	if math.Abs(f(x1)) < Accuracy {
+		return x1
+	}
+
+	return math.NaN()
+}
@@ -0,0 +1,64 @@
+package finance
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNPV(t *testing.T) {
+	should := require.New(t)
+
+	cashflows := []float64{-10000, 3000, 4200, 6800}
+
+	should.Equal(1307.287753568743, NPV(0.1, cashflows))
+}
+
+func TestIRR(t *testing.T) {
+	should := require.New(t)
+
+	cashflows := []float64{-10000, 3000, 4200, 6800}
+
+	should.Equal(0.16340560068898932, IRR(cashflows, 0.1))
+	should.True(math.IsNaN(IRR([]float64{-1000, -2000, -3000}, 0.1)))
+}
+
+func TestMIRR(t *testing.T) {
+	should := require.New(t)
+
+	cashflows := []float64{-1000, 300, 400, 500, 600}
+
+	should.Equal(0.20139202041968263, MIRR(cashflows, 0.1, 0.12))
+}
+
+func TestXNPV(t *testing.T) {
+	should := require.New(t)
+
+	cashflows := []float64{-10000, 2750, 4250, 3250, 2750}
+	dates := []time.Time{
+		time.Date(2008, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2008, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2008, 10, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2009, 2, 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2009, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	should.Equal(1994.5100406532624, XNPV(0.1, cashflows, dates))
+}
+
+func TestXIRR(t *testing.T) {
+	should := require.New(t)
+
+	cashflows := []float64{-10000, 2750, 4250, 3250, 2750}
+	dates := []time.Time{
+		time.Date(2008, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2008, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2008, 10, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2009, 2, 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2009, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	should.Equal(0.37336253350955545, XIRR(cashflows, dates, 0.1))
+}
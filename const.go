@@ -14,3 +14,9 @@ const (
 	EqualPayment = iota
 	EqualPrincipal
 )
+
+// recast modes, used by Loan.RecastMode
+const (
+	RecastPayment = iota
+	ShortenTerm
+)
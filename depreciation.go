@@ -0,0 +1,187 @@
+package finance
+
+import (
+	"math"
+
+	"github.com/hyperjiang/php"
+)
+
+// depreciation methods
+const (
+	StraightLine = iota
+	SumOfYearsDigits
+	DecliningBalance
+	FixedDecliningBalance
+	VariableDecliningBalance
+)
+
+// DepreciationSchedule describes an asset to be depreciated, default
+// method is StraightLine.
+type DepreciationSchedule struct {
+	Cost    float64
+	Salvage float64
+	Life    int
+	Method  int
+}
+
+// DepreciationEntry is the depreciation of a single period.
+type DepreciationEntry struct {
+	Period                  int
+	Depreciation            float64
+	AccumulatedDepreciation float64
+	BookValue               float64
+}
+
+// Schedule returns the per-period depreciation, accumulated
+// depreciation and book value for the whole life of the asset.
+func (ds DepreciationSchedule) Schedule() []DepreciationEntry {
+	capacity := ds.Life
+	if capacity < 0 {
+		capacity = 0
+	}
+
+	entries := make([]DepreciationEntry, 0, capacity)
+
+	var accumulated float64
+	for p := 1; p <= ds.Life; p++ {
+		var dep float64
+		switch ds.Method {
+		case SumOfYearsDigits:
+			dep = SYD(ds.Cost, ds.Salvage, ds.Life, p)
+		case DecliningBalance:
+			dep = DDB(ds.Cost, ds.Salvage, ds.Life, p, 2)
+		case FixedDecliningBalance:
+			dep = DB(ds.Cost, ds.Salvage, ds.Life, p, 12)
+		case VariableDecliningBalance:
+			dep = VDB(ds.Cost, ds.Salvage, ds.Life, p, p, 2, false)
+		default:
+			dep = SLN(ds.Cost, ds.Salvage, ds.Life)
+		}
+
+		accumulated += dep
+
+		entries = append(entries, DepreciationEntry{
+			Period:                  p,
+			Depreciation:            php.Round(dep, Precision),
+			AccumulatedDepreciation: php.Round(accumulated, Precision),
+			BookValue:               php.Round(ds.Cost-accumulated, Precision),
+		})
+	}
+
+	return entries
+}
+
+// SLN returns the straight-line depreciation of an asset for a single
+// period.
+//
+//        cost - salvage
+// SLN = ----------------
+//              life
+//
+func SLN(cost, salvage float64, life int) float64 {
+	return (cost - salvage) / float64(life)
+}
+
+// SYD returns the sum-of-years-digits depreciation of an asset for a
+// specified period.
+//
+//                                   life - per + 1
+// SYD = (cost - salvage) * -------------------------------
+//                            life * (life + 1) / 2
+//
+func SYD(cost, salvage float64, life, per int) float64 {
+	sumOfYears := float64(life*(life+1)) / 2
+
+	return (cost - salvage) * float64(life-per+1) / sumOfYears
+}
+
+// DDB returns the depreciation of an asset for a specified period
+// using the double-declining balance method or some other method
+// specified by factor (2 for double-declining balance).
+func DDB(cost, salvage float64, life, period int, factor float64) float64 {
+	rate := factor / float64(life)
+
+	bookValue := cost
+	dep := 0.0
+	for p := 1; p <= period; p++ {
+		dep = bookValue * rate
+		if bookValue-dep < salvage {
+			dep = bookValue - salvage
+		}
+		if dep < 0 {
+			dep = 0
+		}
+		bookValue -= dep
+	}
+
+	return dep
+}
+
+// DB returns the depreciation of an asset for a specified period using
+// the fixed-declining balance method. month is the number of months in
+// the first year, defaulting to a full year (12) when 0 is passed.
+func DB(cost, salvage float64, life, period, month int) float64 {
+	if month == 0 {
+		month = 12
+	}
+
+	rate := math.Round((1-math.Pow(salvage/cost, 1/float64(life)))*1000) / 1000
+
+	first := cost * rate * float64(month) / 12
+	if period == 1 {
+		return first
+	}
+
+	total := first
+	var dep float64
+	for p := 2; p < period; p++ {
+		dep = (cost - total) * rate
+		total += dep
+	}
+
+	if period == life+1 {
+		dep = (cost - total) * rate * float64(12-month) / 12
+	} else {
+		dep = (cost - total) * rate
+	}
+
+	return dep
+}
+
+// VDB returns the depreciation of an asset for a range of periods
+// (inclusive) using the double-declining balance method (or factor for
+// some other declining-balance rate), switching to straight-line once
+// its remaining-life depreciation exceeds the declining-balance amount
+// unless noSwitch is true.
+func VDB(cost, salvage float64, life, startPeriod, endPeriod int, factor float64, noSwitch bool) float64 {
+	rate := factor / float64(life)
+
+	var total float64
+	bookValue := cost
+	for p := 1; p <= endPeriod; p++ {
+		ddb := bookValue * rate
+		if bookValue-ddb < salvage {
+			ddb = bookValue - salvage
+		}
+
+		dep := ddb
+		if !noSwitch {
+			remainingLife := life - p + 1
+			sln := (bookValue - salvage) / float64(remainingLife)
+			if sln > ddb {
+				dep = sln
+			}
+		}
+
+		if dep < 0 {
+			dep = 0
+		}
+		bookValue -= dep
+
+		if p >= startPeriod {
+			total += dep
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,73 @@
+package finance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSLN(t *testing.T) {
+	should := require.New(t)
+
+	should.Equal(1800.0, SLN(10000, 1000, 5))
+}
+
+func TestSYD(t *testing.T) {
+	should := require.New(t)
+
+	should.Equal(3000.0, SYD(10000, 1000, 5, 1))
+	should.Equal(600.0, SYD(10000, 1000, 5, 5))
+}
+
+func TestDDB(t *testing.T) {
+	should := require.New(t)
+
+	should.Equal(4000.0, DDB(10000, 1000, 5, 1, 2))
+	should.Equal(2400.0, DDB(10000, 1000, 5, 2, 2))
+}
+
+func TestDB(t *testing.T) {
+	should := require.New(t)
+
+	should.Equal(186083.33333333334, DB(1000000, 100000, 6, 1, 7))
+	should.Equal(259639.41666666666, DB(1000000, 100000, 6, 2, 7))
+	should.Equal(15845.098473848071, DB(1000000, 100000, 6, 7, 7))
+}
+
+func TestVDB(t *testing.T) {
+	should := require.New(t)
+
+	should.Equal(480.0, VDB(2400, 300, 10, 0, 1, 2, false))
+}
+
+func TestDepreciationSchedule(t *testing.T) {
+	should := require.New(t)
+
+	ds := DepreciationSchedule{
+		Cost:    10000,
+		Salvage: 1000,
+		Life:    5,
+		Method:  StraightLine,
+	}
+
+	schedule := ds.Schedule()
+
+	should.Len(schedule, 5)
+	should.Equal(1800.0, schedule[0].Depreciation)
+	should.Equal(1800.0, schedule[0].AccumulatedDepreciation)
+	should.Equal(8200.0, schedule[0].BookValue)
+	should.Equal(9000.0, schedule[4].AccumulatedDepreciation)
+	should.Equal(1000.0, schedule[4].BookValue)
+}
+
+func TestDepreciationScheduleNegativeLife(t *testing.T) {
+	should := require.New(t)
+
+	ds := DepreciationSchedule{
+		Cost:    10000,
+		Salvage: 1000,
+		Life:    -1,
+	}
+
+	should.Len(ds.Schedule(), 0)
+}
@@ -77,6 +77,23 @@ func TestNPER(t *testing.T) {
 func TestRATE(t *testing.T) {
 	should := require.New(t)
 
-	should.Equal(-1.4155398849824252, RATE(12, 3612.82, 41817.82, 0.0, 0.0, 0.1))
+	// pv and pmt carry the same sign here, so the cashflow never
+	// changes sign and there is no rate that solves the equation.
+	should.True(math.IsNaN(RATE(12, 3612.82, 41817.82, 0.0, 0.0, 0.1)))
 	should.True(math.IsNaN(RATE(12, 3612.82, 41817.82, 0.0, 0.0, 0.0)))
+
+	// borrow 10000, repay 908.7 per period for 12 periods
+	should.Equal(0.013578257885564817, RATE(12, -908.7, 10000, 0.0, 0.0, 0.1))
+	should.Equal(0.01357825788472232, RATE(12, -908.7, 10000, 0.0, 0.0, 0.0))
+}
+
+func TestRATE2(t *testing.T) {
+	should := require.New(t)
+
+	rate, err := RATE2(12, -908.7, 10000, 0.0, 0.0, 0.0)
+	should.NoError(err)
+	should.Equal(0.01357825788472232, rate)
+
+	_, err = RATE2(12, 3612.82, 41817.82, 0.0, 0.0, 0.1)
+	should.Error(err)
 }
@@ -1,6 +1,9 @@
 package finance
 
-import "math"
+import (
+	"fmt"
+	"math"
+)
 
 // PMT calculates the payment for a loan based on constant payments
 // and a constant interest rate.
@@ -132,41 +135,126 @@ func NPER(rate float64, pmt float64, pv float64, fv float64, due float64) float6
 // The basic rate formula derivation is to solve for the future value
 // taking into account the present value:
 // https://en.wikipedia.org/wiki/Future_value
+//
+// It is a thin wrapper around RATE2 that discards the error and
+// returns NaN instead, kept for backward compatibility.
 func RATE(nper int, pmt float64, pv float64, fv float64, due float64, guess float64) float64 {
-	rate := guess
-	i := 0
-	var x0, x1, y, f float64
+	rate, err := RATE2(nper, pmt, pv, fv, due, guess)
+	if err != nil {
+		return math.NaN()
+	}
 
-	x1 = rate
+	return rate
+}
 
-	lamda := func(rate float64) float64 {
+// rateBoundLower and rateBoundUpper bound the rates RATE2 will
+// consider sane: a rate cannot go below -100% per period, and rates
+// above 1,000,000% per period are not realistic for an annuity.
+const (
+	rateBoundLower = -1 + 1e-9
+	rateBoundUpper = 1e6
+)
+
+// RATE2 calculates interest rate per period of an annuity, the same as
+// RATE, but reports failure to converge instead of silently returning
+// an unconverged iterate.
+//
+// It solves f(rate) = pv*(1+rate)^nper + pmt*(1+rate*due)*((1+rate)^nper-1)/rate + fv = 0
+// by Newton-Raphson from guess using the analytical derivative of f. If
+// an iterate leaves the sane bracket (rateBoundLower, rateBoundUpper)
+// or the derivative is too close to zero, it falls back to bisection
+// on a bracket found by expanding around guess until f changes sign.
+func RATE2(nper int, pmt float64, pv float64, fv float64, due float64, guess float64) (float64, error) {
+	n := float64(nper)
+
+	f := func(rate float64) float64 {
 		if math.Abs(rate) < Accuracy {
-			return pv*(1+float64(nper)*rate) + pmt*(1+rate*due)*float64(nper) + fv
+			return pv*(1+n*rate) + pmt*(1+rate*due)*n + fv
 		}
 
-		f = math.Exp(float64(nper) * math.Log(1+rate))
-		return pv*f + pmt*(1/rate+due)*(f-1) + fv
+		x := math.Pow(1+rate, n)
+		return pv*x + pmt*(1/rate+due)*(x-1) + fv
 	}
 
-	y = lamda(rate)
+	df := func(rate float64) float64 {
+		if math.Abs(rate) < Accuracy {
+			return pv*n + pmt*n*due
+		}
 
-	y0 := pv + pmt*float64(nper) + fv
-	y1 := pv*f + pmt*(1/rate+due)*(f-1) + fv
+		x := math.Pow(1+rate, n)
+		dx := n * math.Pow(1+rate, n-1)
+		return pv*dx + pmt*(-(x-1)/(rate*rate)+(1/rate+due)*dx)
+	}
 
-	// find root by secant method
-	for (math.Abs(y0-y1) > Accuracy) && (i < MaxIterations) {
-		rate = (y1*x0 - y0*x1) / (y1 - y0)
-		x0 = x1
-		x1 = rate
+	rate := guess
+	for i := 0; i < MaxIterations; i++ {
+		y := f(rate)
+		if math.Abs(y) < Accuracy {
+			return rate, nil
+		}
 
-		y = lamda(rate)
+		d := df(rate)
+		if d == 0 || math.IsNaN(d) {
+			break
+		}
 
-		y0 = y1
-		y1 = y
-		i++
+		next := rate - y/d
+		if math.IsNaN(next) || next <= rateBoundLower || next >= rateBoundUpper {
+			break
+		}
+		rate = next
 	}
 
-	return rate
+	if rate, ok := rateBisect(f, guess); ok {
+		return rate, nil
+	}
+
+	return math.NaN(), fmt.Errorf("finance: RATE did not converge for nper=%d, pmt=%v, pv=%v, fv=%v, due=%v, guess=%v", nper, pmt, pv, fv, due, guess)
+}
+
+// rateBisect falls back to bisection once Newton's method fails,
+// expanding a bracket around guess until f changes sign and then
+// bisecting it down to Accuracy.
+func rateBisect(f func(rate float64) float64, guess float64) (float64, bool) {
+	lo, hi := guess, guess
+	step := 0.01
+
+	for i := 0; i < MaxIterations && (lo > rateBoundLower || hi < rateBoundUpper); i++ {
+		if f(lo)*f(hi) < 0 {
+			break
+		}
+
+		if lo > rateBoundLower {
+			lo = math.Max(lo-step, rateBoundLower)
+		}
+		if hi < rateBoundUpper {
+			hi = math.Min(hi+step, rateBoundUpper)
+		}
+		step *= 2
+	}
+
+	yLo, yHi := f(lo), f(hi)
+	if yLo*yHi > 0 {
+		return 0, false
+	}
+
+	for i := 0; i < MaxIterations; i++ {
+		mid := (lo + hi) / 2
+		yMid := f(mid)
+		if math.Abs(yMid) < Accuracy {
+			return mid, true
+		}
+
+		if (yMid >= 0) == (yLo >= 0) {
+			lo = mid
+			yLo = yMid
+		} else {
+			hi = mid
+			yHi = yMid
+		}
+	}
+
+	return (lo + hi) / 2, true
 }
 
 // Present value interest factor
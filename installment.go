@@ -1,6 +1,10 @@
 package finance
 
-import "github.com/hyperjiang/php"
+import (
+	"time"
+
+	"github.com/hyperjiang/php"
+)
 
 // Loan is a loan, default method is EqualPayment
 type Loan struct {
@@ -8,6 +12,18 @@ type Loan struct {
 	Periods    int
 	AnnualRate float64
 	Method     int
+
+	// PrepaymentPlan maps a period to an extra principal payment made
+	// in that period, on top of the regular amortization. It is only
+	// consulted by CalculateInstallmentsWithDates.
+	PrepaymentPlan map[int]float64
+
+	// RecastMode controls how the amortization schedule reacts to a
+	// prepayment: RecastPayment (the default) recalculates the
+	// payment for the remaining periods, ShortenTerm keeps the
+	// original payment (under EqualPrincipal, the original principal
+	// of each period) and pays the loan off early instead.
+	RecastMode int
 }
 
 // Installment is an installment
@@ -19,6 +35,13 @@ type Installment struct {
 	RemainingAmount float64
 }
 
+// PaymentDate pairs a payment date with the principal that is expected
+// to be repaid on it, before any prepayment is applied.
+type PaymentDate struct {
+	Date              time.Time
+	ExpectedPrincipal float64
+}
+
 // CalculatePayment calculates payment in given period
 func (loan Loan) CalculatePayment(period int) float64 {
 	return loan.CalculatePrincipal(period) + loan.CalculateInterest(period)
@@ -63,16 +86,238 @@ func (loan Loan) CalculateTotalInterest() float64 {
 	return php.Round(loan.CalculateTotalPayment()-loan.Amount, Precision)
 }
 
-// CalculateInstallments calculates installments
+// CalculateInstallments calculates installments for the full loan term
 func (loan Loan) CalculateInstallments() []Installment {
-	var installments []Installment
-	for p := 1; p < loan.Periods; p++ {
+	return loan.CalculateInstallmentsRange(1, loan.Periods)
+}
+
+// CalculateInstallmentsRange calculates installments for periods start
+// through end (inclusive), without building the full schedule first.
+func (loan Loan) CalculateInstallmentsRange(start, end int) []Installment {
+	if end < start {
+		return nil
+	}
+
+	installments := make([]Installment, 0, end-start+1)
+
+	loan.EachInstallment(func(installment Installment) bool {
+		if installment.Period < start {
+			return true
+		}
+		if installment.Period > end {
+			return false
+		}
+
+		installments = append(installments, installment)
+		return true
+	})
+
+	return installments
+}
+
+// EachInstallment streams installments one period at a time, calling
+// yield for each and stopping early if yield returns false. This
+// avoids building the full schedule in memory for loans with a large
+// number of Periods.
+func (loan Loan) EachInstallment(yield func(Installment) bool) {
+	remaining := loan.Amount
+
+	for p := 1; p <= loan.Periods; p++ {
 		var installment Installment
 		installment.Period = p
 		installment.Payment = php.Round(loan.CalculatePayment(p), Precision)
 		installment.Principal = php.Round(loan.CalculatePrincipal(p), Precision)
 		installment.Interest = php.Round(loan.CalculateInterest(p), Precision)
-		installment.RemainingAmount = php.Round(loan.Amount-installment.Principal, Precision)
+
+		remaining = php.Round(remaining-installment.Principal, Precision)
+		installment.RemainingAmount = remaining
+
+		if !yield(installment) {
+			return
+		}
+	}
+}
+
+// CalculateInstallmentsWithDates calculates installments for a loan
+// whose payment dates are not evenly spaced monthly periods. Interest
+// for each period is accrued as principal * AnnualRate * daysBetween /
+// 365 between successive dates, rather than assuming AnnualRate/12 per
+// period. dates must start with the disbursement date, followed by one
+// date per payment period.
+//
+// loan.Method is honored the same way as the regular schedule methods:
+// EqualPrincipal repays the same principal every period, while the
+// default EqualPayment solves for the level payment that amortizes the
+// loan to zero under the actual day-count schedule.
+//
+// If loan.PrepaymentPlan has an entry for a period, the extra
+// principal is applied on top of the period's expected principal and
+// the remaining schedule is adjusted according to loan.RecastMode.
+func (loan Loan) CalculateInstallmentsWithDates(dates []time.Time) []Installment {
+	if len(dates) < 2 {
+		return nil
+	}
+
+	n := len(dates) - 1
+	schedule := make([]PaymentDate, n)
+
+	var principals []float64
+	if loan.Method == EqualPrincipal {
+		principals = equalPrincipals(loan.Amount, n)
+	} else {
+		principals = equalPaymentPrincipals(loan.Amount, loan.AnnualRate, dates[0], dates[1:])
+	}
+
+	for i := range schedule {
+		schedule[i] = PaymentDate{Date: dates[i+1], ExpectedPrincipal: principals[i]}
+	}
+
+	return loan.amortizeWithDates(dates[0], schedule)
+}
+
+// equalPrincipals returns the same principal, amount/n, repeated n
+// times.
+func equalPrincipals(amount float64, n int) []float64 {
+	principal := amount / float64(n)
+
+	principals := make([]float64, n)
+	for i := range principals {
+		principals[i] = principal
+	}
+
+	return principals
+}
+
+// equalPaymentPrincipals returns the principal due on each of dates so
+// that a loan of amount, accruing interest at annualRate on an
+// Actual/365 day-count between start and successive dates, amortizes
+// to exactly zero by the last date.
+func equalPaymentPrincipals(amount, annualRate float64, start time.Time, dates []time.Time) []float64 {
+	payment := equalLevelPayment(amount, annualRate, start, dates)
+
+	principals := make([]float64, len(dates))
+	remaining := amount
+	prev := start
+	for i, d := range dates {
+		days := d.Sub(prev).Hours() / 24
+		interest := remaining * annualRate * days / 365
+		principal := payment - interest
+
+		principals[i] = principal
+		remaining -= principal
+		prev = d
+	}
+
+	return principals
+}
+
+// equalLevelPayment solves for the level payment that amortizes amount
+// to zero over dates, given interest accrues at annualRate on an
+// Actual/365 day-count between start and successive dates.
+//
+// Expanding the recurrence remaining_i = remaining_{i-1}*(1+rate_i) -
+// payment shows the balance after the last date is an affine function
+// of payment, so the level payment can be solved for directly instead
+// of iterating like RATE/IRR do.
+func equalLevelPayment(amount, annualRate float64, start time.Time, dates []time.Time) float64 {
+	n := len(dates)
+	rates := make([]float64, n)
+	prev := start
+	for i, d := range dates {
+		days := d.Sub(prev).Hours() / 24
+		rates[i] = annualRate * days / 365
+		prev = d
+	}
+
+	growth := 1.0
+	for _, r := range rates {
+		growth *= 1 + r
+	}
+
+	// weight[k] = prod_{j=k+1}^{n-1} (1 + rates[j])
+	var denom float64
+	acc := 1.0
+	for k := n - 1; k >= 0; k-- {
+		denom += acc
+		acc *= 1 + rates[k]
+	}
+
+	return amount * growth / denom
+}
+
+// amortizeWithDates walks a payment schedule from start, applying any
+// configured PrepaymentPlan, and returns the resulting installments.
+func (loan Loan) amortizeWithDates(start time.Time, schedule []PaymentDate) []Installment {
+	installments := make([]Installment, 0, len(schedule))
+
+	remaining := loan.Amount
+	prev := start
+
+	// levelPayment is the payment of the original schedule, which
+	// ShortenTerm keeps after a prepayment under EqualPayment.
+	var levelPayment float64
+	if len(schedule) > 0 {
+		days := schedule[0].Date.Sub(start).Hours() / 24
+		levelPayment = schedule[0].ExpectedPrincipal + loan.Amount*loan.AnnualRate*days/365
+	}
+	prepaid := false
+
+	for i, pd := range schedule {
+		period := i + 1
+		days := pd.Date.Sub(prev).Hours() / 24
+		interest := remaining * loan.AnnualRate * days / 365
+
+		principal := pd.ExpectedPrincipal
+		if prepaid && loan.RecastMode == ShortenTerm && loan.Method != EqualPrincipal {
+			principal = levelPayment - interest
+		}
+		if extra, ok := loan.PrepaymentPlan[period]; ok {
+			principal += extra
+			prepaid = true
+
+			remainingPeriods := len(schedule) - period
+			if loan.RecastMode == RecastPayment && remainingPeriods > 0 {
+				remainingAfter := remaining - principal
+
+				var recastPrincipals []float64
+				if loan.Method == EqualPrincipal {
+					recastPrincipals = equalPrincipals(remainingAfter, remainingPeriods)
+				} else {
+					remainingDates := make([]time.Time, remainingPeriods)
+					for j := 0; j < remainingPeriods; j++ {
+						remainingDates[j] = schedule[period+j].Date
+					}
+					recastPrincipals = equalPaymentPrincipals(remainingAfter, loan.AnnualRate, pd.Date, remainingDates)
+				}
+
+				for j, p := range recastPrincipals {
+					schedule[period+j].ExpectedPrincipal = p
+				}
+			}
+			// ShortenTerm leaves the remaining schedule untouched: the
+			// extra principal simply pays the loan off sooner, and the
+			// loop below stops once nothing remains.
+		}
+
+		if principal > remaining {
+			principal = remaining
+		}
+
+		remaining = php.Round(remaining-principal, Precision)
+
+		installments = append(installments, Installment{
+			Period:          period,
+			Payment:         php.Round(principal+interest, Precision),
+			Principal:       php.Round(principal, Precision),
+			Interest:        php.Round(interest, Precision),
+			RemainingAmount: remaining,
+		})
+
+		prev = pd.Date
+
+		if remaining <= 0 {
+			break
+		}
 	}
 
 	return installments
@@ -0,0 +1,142 @@
+package finance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateInstallmentsWithDates(t *testing.T) {
+	should := require.New(t)
+
+	dates := []time.Time{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	loan := Loan{
+		Amount:     120000,
+		AnnualRate: 0.12,
+		Method:     EqualPrincipal,
+	}
+
+	installments := loan.CalculateInstallmentsWithDates(dates)
+
+	should.Len(installments, 4)
+	should.Equal(30000.0, installments[0].Principal)
+	should.Equal(1223.01, installments[0].Interest)
+	should.Equal(0.0, installments[3].RemainingAmount)
+}
+
+func TestCalculateInstallmentsWithDatesEqualPayment(t *testing.T) {
+	should := require.New(t)
+
+	dates := []time.Time{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	// Method defaults to EqualPayment, the same default as Loan itself.
+	loan := Loan{
+		Amount:     120000,
+		AnnualRate: 0.12,
+	}
+
+	installments := loan.CalculateInstallmentsWithDates(dates)
+
+	should.Len(installments, 4)
+	for _, installment := range installments {
+		should.Equal(30750.85, installment.Payment)
+	}
+
+	// The level payment amortizes the loan to (near) zero; a cent or
+	// two of residual rounding is expected, same as CalculateInstallments.
+	should.Equal(0.01, installments[3].RemainingAmount)
+}
+
+func TestCalculateInstallmentsWithDatesAndPrepaymentRecast(t *testing.T) {
+	should := require.New(t)
+
+	dates := []time.Time{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	loan := Loan{
+		Amount:         120000,
+		AnnualRate:     0.12,
+		Method:         EqualPrincipal,
+		PrepaymentPlan: map[int]float64{2: 20000},
+		RecastMode:     RecastPayment,
+	}
+
+	installments := loan.CalculateInstallmentsWithDates(dates)
+
+	should.Equal(50000.0, installments[1].Principal)
+	should.Equal(20000.0, installments[2].Principal)
+	should.Equal(20000.0, installments[3].Principal)
+	should.Equal(0.0, installments[3].RemainingAmount)
+}
+
+func TestCalculateInstallmentsWithDatesAndPrepaymentShortenTerm(t *testing.T) {
+	should := require.New(t)
+
+	dates := []time.Time{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	loan := Loan{
+		Amount:         120000,
+		AnnualRate:     0.12,
+		Method:         EqualPrincipal,
+		PrepaymentPlan: map[int]float64{2: 20000},
+		RecastMode:     ShortenTerm,
+	}
+
+	installments := loan.CalculateInstallmentsWithDates(dates)
+
+	should.Equal(30000.0, installments[2].Principal)
+	should.Equal(10000.0, installments[3].Principal)
+	should.Equal(0.0, installments[3].RemainingAmount)
+}
+
+func TestCalculateInstallmentsWithDatesEqualPaymentAndPrepaymentRecast(t *testing.T) {
+	should := require.New(t)
+
+	dates := []time.Time{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	loan := Loan{
+		Amount:         120000,
+		AnnualRate:     0.12,
+		PrepaymentPlan: map[int]float64{2: 20000},
+		RecastMode:     RecastPayment,
+	}
+
+	installments := loan.CalculateInstallmentsWithDates(dates)
+
+	should.Equal(30750.85, installments[0].Payment)
+	should.Equal(50750.85, installments[1].Payment)
+	should.Equal(20599.36, installments[2].Payment)
+	should.Equal(20599.36, installments[3].Payment)
+	should.Equal(0.0, installments[3].RemainingAmount)
+}
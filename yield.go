@@ -0,0 +1,64 @@
+package finance
+
+import "math"
+
+// BDY returns the bank discount yield of a T-bill or similar
+// money-market instrument bought at price and redeemed at face on
+// maturity, quoted on a 360-day year.
+//
+//              face - price       360
+// BDY = ------------------- * -----------------
+//               face           daysToMaturity
+//
+func BDY(face, price float64, daysToMaturity int) float64 {
+	return (face - price) / face * 360 / float64(daysToMaturity)
+}
+
+// MMY returns the money-market yield (CD-equivalent yield) of an
+// instrument bought at price and redeemed at face on maturity, quoted
+// on a 360-day year.
+//
+//              face - price       360
+// MMY = ------------------- * -----------------
+//               price           daysToMaturity
+//
+func MMY(face, price float64, daysToMaturity int) float64 {
+	return (face - price) / price * 360 / float64(daysToMaturity)
+}
+
+// HPY returns the holding-period yield of an investment bought at p0
+// and sold at p1 after receiving cash distribution d1 over the
+// holding period.
+//
+//        p1 - p0 + d1
+// HPY = --------------
+//             p0
+//
+func HPY(p0, p1, d1 float64) float64 {
+	return (p1 - p0 + d1) / p0
+}
+
+// EAY returns the effective annual yield for a holding-period yield
+// hpy realized over days days.
+//
+//                        365/days
+// EAY = (1 + hpy)                 - 1
+//
+func EAY(hpy float64, days int) float64 {
+	return math.Pow(1+hpy, 365/float64(days)) - 1
+}
+
+// BDYToMMY converts a bank discount yield to the equivalent
+// money-market yield for an instrument with daysToMaturity days
+// remaining.
+func BDYToMMY(bdy float64, daysToMaturity int) float64 {
+	d := float64(daysToMaturity)
+	return 360 * bdy / (360 - d*bdy)
+}
+
+// MMYToBDY converts a money-market yield to the equivalent bank
+// discount yield for an instrument with daysToMaturity days remaining.
+func MMYToBDY(mmy float64, daysToMaturity int) float64 {
+	d := float64(daysToMaturity)
+	return 360 * mmy / (360 + d*mmy)
+}
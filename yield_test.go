@@ -0,0 +1,43 @@
+package finance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBDY(t *testing.T) {
+	should := require.New(t)
+
+	should.Equal(0.08, BDY(10000, 9800, 90))
+}
+
+func TestMMY(t *testing.T) {
+	should := require.New(t)
+
+	should.Equal(0.08163265306122448, MMY(10000, 9800, 90))
+}
+
+func TestHPY(t *testing.T) {
+	should := require.New(t)
+
+	should.Equal(0.055, HPY(10000, 10500, 50))
+}
+
+func TestEAY(t *testing.T) {
+	should := require.New(t)
+
+	should.Equal(0.11468157078201857, EAY(0.055, 180))
+}
+
+func TestBDYToMMY(t *testing.T) {
+	should := require.New(t)
+
+	should.Equal(0.08163265306122448, BDYToMMY(0.08, 90))
+}
+
+func TestMMYToBDY(t *testing.T) {
+	should := require.New(t)
+
+	should.Equal(0.08, MMYToBDY(0.08163265306122448, 90))
+}